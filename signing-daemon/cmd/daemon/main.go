@@ -9,14 +9,25 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/enshrined-relayers/signing-daemon/internal/auth"
 	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+	"github.com/enshrined-relayers/signing-daemon/internal/transparency"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// SignRequest represents a request to sign a digest
+// SignRequest represents a request to sign a digest or message. Exactly one
+// of DigestHex (for secp256k1 operators) or MessageHex (for Ed25519
+// operators, which sign the raw message rather than a pre-hashed digest)
+// must be set, matching the operator's key algorithm.
 type SignRequest struct {
 	OperatorBech32 string `json:"operatorBech32"`
-	DigestHex      string `json:"digestHex"`
+	DigestHex      string `json:"digestHex,omitempty"`
+	MessageHex     string `json:"messageHex,omitempty"`
 }
 
 // SignResponse represents a response containing a signature
@@ -27,12 +38,17 @@ type SignResponse struct {
 
 // Server represents the signing daemon server
 type Server struct {
-	keyStore *keys.KeyStore
-	port     string
+	keyStore        *keys.KeyStore
+	port            string
+	passphrase      string
+	ledgerSigners   map[string]*keys.LedgerSigner // operator address -> bound ledger signer
+	authVerifier    *auth.Verifier                // nil disables JWT auth on /sign
+	transparencyLog *transparency.Store
+	logSigner       keys.Signer // nil if no keystore entry is flagged role:"log"
 }
 
 // NewServer creates a new signing daemon server
-func NewServer(keyStorePath, port string) (*Server, error) {
+func NewServer(keyStorePath, port, passphrase, auditLogDir string, authVerifier *auth.Verifier) (*Server, error) {
 	// Try to load existing key store, create sample if not found
 	keyStore, err := keys.LoadKeyStore(keyStorePath)
 	if err != nil {
@@ -48,10 +64,110 @@ func NewServer(keyStorePath, port string) (*Server, error) {
 		}
 	}
 
-	return &Server{
-		keyStore: keyStore,
-		port:     port,
-	}, nil
+	transparencyLog, err := transparency.Open(auditLogDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transparency log: %w", err)
+	}
+
+	server := &Server{
+		keyStore:        keyStore,
+		port:            port,
+		passphrase:      passphrase,
+		authVerifier:    authVerifier,
+		transparencyLog: transparencyLog,
+	}
+	server.refreshLedgerSigners()
+
+	if logOperator, ok := keyStore.LogSignerOperator(); ok {
+		logSigner, err := keyStore.GetSigner(logOperator, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transparency log signing key (operator %s): %w", logOperator, err)
+		}
+		server.logSigner = logSigner
+	} else {
+		log.Printf("WARNING: no keystore entry flagged role:\"log\"; /log/sth will be unavailable")
+	}
+
+	return server, nil
+}
+
+// refreshLedgerSigners queries connected Ledger devices over HID and binds
+// each one, in order of operator address (sorted, so binding is
+// deterministic across restarts and refreshes rather than following Go's
+// randomized map iteration order), to the next unbound "ledger" keystore
+// entry. If the entry records an expected PubKeyHex, the bound device's
+// public key must match it or the binding is rejected; entries without one
+// are bound without a check, but the unverified binding is logged loudly so an
+// operator address is never silently signed for by the wrong hardware
+// key. Operators whose entry can't be bound to a device are logged and
+// left unable to sign until a device becomes available.
+func (s *Server) refreshLedgerSigners() {
+	for _, signer := range s.ledgerSigners {
+		signer.Close()
+	}
+
+	devices, err := keys.DiscoverLedgers()
+	if err != nil {
+		log.Printf("Ledger discovery failed: %v", err)
+		s.ledgerSigners = nil
+		return
+	}
+
+	var operatorAddrs []string
+	for operatorAddr, entry := range s.keyStore.Keys {
+		if entry.Type == keys.KeyEntryTypeLedger {
+			operatorAddrs = append(operatorAddrs, operatorAddr)
+		}
+	}
+	sort.Strings(operatorAddrs)
+
+	bound := make(map[string]*keys.LedgerSigner)
+	next := 0
+	for _, operatorAddr := range operatorAddrs {
+		entry := s.keyStore.Keys[operatorAddr]
+		if next >= len(devices) {
+			log.Printf("No connected ledger device available for operator %s", operatorAddr)
+			continue
+		}
+
+		signer, err := keys.NewLedgerSigner(operatorAddr, entry.Path, devices[next])
+		next++
+		if err != nil {
+			log.Printf("Failed to bind ledger signer for operator %s: %v", operatorAddr, err)
+			continue
+		}
+
+		if entry.PubKeyHex == "" {
+			log.Printf("WARNING: ledger entry for operator %s has no expected pubKeyHex; binding whatever device enumerates next without verification", operatorAddr)
+		} else if got := hex.EncodeToString(signer.PubKey().Bytes()); got != entry.PubKeyHex {
+			log.Printf("Ledger device bound for operator %s does not match its expected pubkey (got %s, want %s); refusing to bind", operatorAddr, got, entry.PubKeyHex)
+			signer.Close()
+			continue
+		}
+
+		bound[operatorAddr] = signer
+	}
+	s.ledgerSigners = bound
+}
+
+// resolveSigner returns the keys.Signer to use for operatorAddr, whether
+// it's backed by the keystore (plaintext/encrypted) or a bound ledger
+// device.
+func (s *Server) resolveSigner(operatorAddr string) (keys.Signer, error) {
+	entry, exists := s.keyStore.Keys[operatorAddr]
+	if !exists {
+		return nil, fmt.Errorf("private key not found for operator: %s", operatorAddr)
+	}
+
+	if entry.Type == keys.KeyEntryTypeLedger {
+		signer, ok := s.ledgerSigners[operatorAddr]
+		if !ok {
+			return nil, fmt.Errorf("no ledger device bound for operator: %s", operatorAddr)
+		}
+		return signer, nil
+	}
+
+	return s.keyStore.GetSigner(operatorAddr, s.passphrase)
 }
 
 // Start starts the HTTP server
@@ -59,12 +175,28 @@ func (s *Server) Start() error {
 	http.HandleFunc("/sign", s.handleSign)
 	http.HandleFunc("/health", s.handleHealth)
 	http.HandleFunc("/pubkeys", s.handlePubKeys)
+	http.HandleFunc("/pubkey/", s.handlePubKey)
+	http.HandleFunc("/log/sth", s.handleLogSTH)
+	http.HandleFunc("/log/proof", s.handleLogProof)
+	http.HandleFunc("/log/consistency", s.handleLogConsistency)
 
 	log.Printf("Starting signing daemon on port %s", s.port)
 	log.Printf("Endpoints:")
-	log.Printf("  POST /sign - Sign a digest")
+	log.Printf("  POST /sign - Sign a digest (secp256k1) or message (Ed25519)")
 	log.Printf("  GET /health - Health check")
-	log.Printf("  GET /pubkeys - List public keys")
+	log.Printf("  GET /pubkeys - List public keys (?refresh=true to re-scan ledger devices)")
+	log.Printf("  GET /pubkey/{operator} - Canonical amino-prefixed public key for one operator")
+	log.Printf("  GET /log/sth - Signed tree head of the transparency log")
+	log.Printf("  GET /log/proof?leaf=<hex>&size=<n> - Inclusion proof for a logged signature")
+	log.Printf("  GET /log/consistency?from=<a>&to=<b> - Consistency proof between two tree sizes")
+
+	if s.authVerifier != nil {
+		http.HandleFunc("/token/introspect", s.handleTokenIntrospect)
+		log.Printf("  POST /token/introspect - Debug: verify and decode a bearer token")
+		log.Printf("/sign requires a valid bearer JWT (--trust-jwks is configured)")
+	} else {
+		log.Printf("WARNING: --trust-jwks not configured, /sign is unauthenticated")
+	}
 
 	return http.ListenAndServe(":"+s.port, nil)
 }
@@ -89,34 +221,97 @@ func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.DigestHex == "" {
-		s.sendErrorResponse(w, http.StatusBadRequest, "digestHex is required")
+	// Resolve the signer backing this operator, whether keystore- or
+	// ledger-backed, so we know which algorithm's request fields to expect
+	signer, err := s.resolveSigner(req.OperatorBech32)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Private key not found for operator: %s", req.OperatorBech32))
 		return
 	}
 
-	// Decode digest
-	digest, err := hex.DecodeString(req.DigestHex)
-	if err != nil {
-		s.sendErrorResponse(w, http.StatusBadRequest, "Invalid digestHex format")
-		return
+	var msg []byte
+	switch signer.PubKey().Type() {
+	case keys.AlgoEd25519:
+		if req.DigestHex != "" {
+			s.sendErrorResponse(w, http.StatusBadRequest, "digestHex is not supported for Ed25519 keys; use messageHex")
+			return
+		}
+		if req.MessageHex == "" {
+			s.sendErrorResponse(w, http.StatusBadRequest, "messageHex is required")
+			return
+		}
+		decoded, err := hex.DecodeString(req.MessageHex)
+		if err != nil {
+			s.sendErrorResponse(w, http.StatusBadRequest, "Invalid messageHex format")
+			return
+		}
+		msg = decoded
+	default:
+		if req.MessageHex != "" {
+			s.sendErrorResponse(w, http.StatusBadRequest, "messageHex is not supported for secp256k1 keys; use digestHex")
+			return
+		}
+		if req.DigestHex == "" {
+			s.sendErrorResponse(w, http.StatusBadRequest, "digestHex is required")
+			return
+		}
+		digest, err := hex.DecodeString(req.DigestHex)
+		if err != nil {
+			s.sendErrorResponse(w, http.StatusBadRequest, "Invalid digestHex format")
+			return
+		}
+		if len(digest) != 32 {
+			s.sendErrorResponse(w, http.StatusBadRequest, "digest must be exactly 32 bytes")
+			return
+		}
+		msg = digest
 	}
 
-	if len(digest) != 32 {
-		s.sendErrorResponse(w, http.StatusBadRequest, "digest must be exactly 32 bytes")
-		return
+	// Require and verify a bearer JWT if JWT auth is configured, binding
+	// the token to the exact digest/message it authorizes so a captured
+	// token can't be replayed against a different signing request.
+	if s.authVerifier != nil {
+		token, err := auth.BearerToken(r)
+		if err != nil {
+			s.sendErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		expected := req.DigestHex
+		if expected == "" {
+			expected = req.MessageHex
+		}
+		if _, err := s.authVerifier.VerifyDigest(token, expected); err != nil {
+			s.sendErrorResponse(w, http.StatusUnauthorized, fmt.Sprintf("token verification failed: %v", err))
+			return
+		}
 	}
 
-	// Get private key
-	privateKey, err := s.keyStore.GetPrivateKey(req.OperatorBech32)
+	// Sign the digest/message
+	signature, err := signer.Sign(msg)
 	if err != nil {
-		s.sendErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Private key not found for operator: %s", req.OperatorBech32))
+		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to sign: %v", err))
 		return
 	}
 
-	// Sign the digest
-	signature, err := keys.SignDigest(privateKey, digest)
+	// Record the signature in the transparency log before telling the
+	// caller it succeeded. Signing must fail closed: if the audit log
+	// can't durably record what was signed, we don't hand out the
+	// signature either.
+	leafRecord := transparency.LeafRecord{
+		Timestamp: time.Now().UnixMilli(),
+		Operator:  req.OperatorBech32,
+		DigestHex: msgHexForAudit(req),
+		SigHex:    hex.EncodeToString(signature),
+		PubkeyHex: hex.EncodeToString(signer.PubKey().Bytes()),
+	}
+	leafData, err := json.Marshal(leafRecord)
 	if err != nil {
-		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to sign digest: %v", err))
+		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode audit log entry: %v", err))
+		return
+	}
+	if _, err := s.transparencyLog.Append(leafData); err != nil {
+		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to append to transparency log: %v", err))
 		return
 	}
 
@@ -131,6 +326,15 @@ func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Signed digest for operator %s", req.OperatorBech32)
 }
 
+// msgHexForAudit returns whichever of req.DigestHex/req.MessageHex was
+// actually set, for recording in the transparency log's leaf record.
+func msgHexForAudit(req SignRequest) string {
+	if req.DigestHex != "" {
+		return req.DigestHex
+	}
+	return req.MessageHex
+}
+
 // handleHealth handles health check requests
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -147,23 +351,35 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// handlePubKeys handles requests to list public keys
+// handlePubKeys handles requests to list public keys. Passing
+// ?refresh=true re-queries connected ledger devices before responding,
+// picking up devices that were plugged in after the daemon started.
 func (s *Server) handlePubKeys(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	pubKeys := make(map[string]string)
+	if r.URL.Query().Get("refresh") == "true" {
+		s.refreshLedgerSigners()
+	}
+
+	type pubKeyInfo struct {
+		Type   string `json:"type"`
+		PubKey string `json:"pubkey"`
+	}
+	pubKeys := make(map[string]pubKeyInfo)
 
 	for operatorAddr := range s.keyStore.Keys {
-		privateKey, err := s.keyStore.GetPrivateKey(operatorAddr)
+		signer, err := s.resolveSigner(operatorAddr)
 		if err != nil {
 			continue
 		}
 
-		pubKeyBytes := keys.PublicKeyToBytes(&privateKey.PublicKey)
-		pubKeys[operatorAddr] = hex.EncodeToString(pubKeyBytes)
+		pubKeys[operatorAddr] = pubKeyInfo{
+			Type:   signer.PubKey().Type(),
+			PubKey: hex.EncodeToString(signer.PubKey().Bytes()),
+		}
 	}
 
 	response := map[string]interface{}{
@@ -174,6 +390,183 @@ func (s *Server) handlePubKeys(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// handlePubKey handles requests for a single operator's canonical
+// amino-style prefixed public key, so downstream verifiers can pick the
+// right algorithm without out-of-band coordination.
+func (s *Server) handlePubKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	operatorAddr := strings.TrimPrefix(r.URL.Path, "/pubkey/")
+	if operatorAddr == "" {
+		s.sendErrorResponse(w, http.StatusBadRequest, "operator address is required")
+		return
+	}
+
+	signer, err := s.resolveSigner(operatorAddr)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusNotFound, fmt.Sprintf("public key not found for operator: %s", operatorAddr))
+		return
+	}
+
+	aminoBytes, err := keys.AminoPubKeyBytes(signer.PubKey())
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode public key: %v", err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"operator": operatorAddr,
+		"type":     signer.PubKey().Type(),
+		"pubkey":   hex.EncodeToString(aminoBytes),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleLogSTH returns a freshly signed tree head over the transparency
+// log's current state.
+func (s *Server) handleLogSTH(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.logSigner == nil {
+		s.sendErrorResponse(w, http.StatusServiceUnavailable, "no transparency log signing key configured (keystore entry with role:\"log\")")
+		return
+	}
+
+	rootHash, size := s.transparencyLog.Root()
+	sth, err := transparency.SignSTH(s.logSigner, size, rootHash)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to sign tree head: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sth)
+}
+
+// handleLogProof returns an RFC 6962 inclusion proof for the leaf hash
+// given in the "leaf" query parameter, against the tree size given in
+// "size".
+func (s *Server) handleLogProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	leafHex := r.URL.Query().Get("leaf")
+	sizeStr := r.URL.Query().Get("size")
+	if leafHex == "" || sizeStr == "" {
+		s.sendErrorResponse(w, http.StatusBadRequest, "leaf and size query parameters are required")
+		return
+	}
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusBadRequest, "invalid size")
+		return
+	}
+
+	leafIndex, proof, err := s.transparencyLog.InclusionProof(leafHex, size)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response := map[string]interface{}{
+		"leafIndex": leafIndex,
+		"treeSize":  size,
+		"proof":     hashesToHex(proof),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleLogConsistency returns an RFC 6962 consistency proof between the
+// tree sizes given in the "from" and "to" query parameters.
+func (s *Server) handleLogConsistency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, errFrom := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	to, errTo := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		s.sendErrorResponse(w, http.StatusBadRequest, "from and to query parameters must be integers")
+		return
+	}
+
+	proof, err := s.transparencyLog.ConsistencyProof(from, to)
+	if err != nil {
+		s.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"proof": hashesToHex(proof),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// hashesToHex hex-encodes a slice of 32-byte hashes for JSON responses.
+func hashesToHex(hashes [][32]byte) []string {
+	out := make([]string, len(hashes))
+	for i, h := range hashes {
+		out[i] = hex.EncodeToString(h[:])
+	}
+	return out
+}
+
+// handleTokenIntrospect is a debug endpoint that verifies a bearer token
+// (accepted via Authorization header or a {"token": "..."} JSON body) and
+// reports its verified claims, without checking any digest claim. It's
+// only registered when --trust-jwks is configured.
+func (s *Server) handleTokenIntrospect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Token string `json:"token"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if req.Token == "" {
+		if token, err := auth.BearerToken(r); err == nil {
+			req.Token = token
+		}
+	}
+	if req.Token == "" {
+		s.sendErrorResponse(w, http.StatusBadRequest, "token is required (JSON body or Authorization header)")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, err := s.authVerifier.VerifyAndParse(req.Token)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"active": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"active": true,
+		"claims": claims,
+	})
+}
+
 // sendErrorResponse sends an error response
 func (s *Server) sendErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -187,14 +580,64 @@ func (s *Server) sendErrorResponse(w http.ResponseWriter, statusCode int, messag
 	log.Printf("Error: %s", message)
 }
 
+// loadPassphrase resolves the keystore passphrase from --passphrase-file if
+// set, falling back to the SIGNER_PASSPHRASE environment variable.
+func loadPassphrase(passphraseFile string) (string, error) {
+	if passphraseFile != "" {
+		data, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	return os.Getenv("SIGNER_PASSPHRASE"), nil
+}
+
 func main() {
 	var (
-		keyStorePath = flag.String("keys", "keys.json", "Path to the key store file")
-		port         = flag.String("port", "8080", "Port to listen on")
-		generateKeys = flag.Bool("generate", false, "Generate sample keys and exit")
+		keyStorePath   = flag.String("keys", "keys.json", "Path to the key store file")
+		port           = flag.String("port", "8080", "Port to listen on")
+		generateKeys   = flag.Bool("generate", false, "Generate sample keys and exit")
+		passphraseFile = flag.String("passphrase-file", "", "Path to a file containing the keystore passphrase (falls back to SIGNER_PASSPHRASE)")
+		trustJWKS      = flag.String("trust-jwks", "", "Path to a JWKS file of authorization public keys trusted to authorize /sign; enables JWT auth when set")
+		jwtAudience    = flag.String("jwt-audience", "signing-daemon", "Required aud claim on /sign bearer tokens")
+		auditLogDir    = flag.String("audit-log-dir", "audit-log", "Directory for the append-only transparency log of sign operations")
 	)
 	flag.Parse()
 
+	if flag.Arg(0) == "mint-token" {
+		runMintToken(flag.Args()[1:])
+		return
+	}
+
+	if flag.Arg(0) == "verify-log" {
+		runVerifyLog(flag.Args()[1:])
+		return
+	}
+
+	if flag.Arg(0) == "migrate" {
+		passphrase, err := loadPassphrase(*passphraseFile)
+		if err != nil {
+			log.Fatalf("Failed to load passphrase: %v", err)
+		}
+		if passphrase == "" {
+			log.Fatal("A passphrase is required to migrate the key store (--passphrase-file or SIGNER_PASSPHRASE)")
+		}
+
+		keyStore, err := keys.LoadKeyStore(*keyStorePath)
+		if err != nil {
+			log.Fatalf("Failed to load key store: %v", err)
+		}
+		if err := keyStore.MigrateToEncrypted(passphrase); err != nil {
+			log.Fatalf("Failed to migrate key store: %v", err)
+		}
+		if err := keyStore.SaveKeyStore(*keyStorePath); err != nil {
+			log.Fatalf("Failed to save migrated key store: %v", err)
+		}
+		log.Printf("Key store at %s migrated to encrypted-v3", *keyStorePath)
+		return
+	}
+
 	if *generateKeys {
 		log.Println("Generating sample key store...")
 		keyStore := keys.CreateSampleKeyStore()
@@ -205,21 +648,37 @@ func main() {
 
 		// Print public keys for reference
 		fmt.Println("\nGenerated validator keys:")
-		for operatorAddr, privateKeyHex := range keyStore.Keys {
-			privateKeyBytes, _ := hex.DecodeString(privateKeyHex)
-			privateKey, _ := keys.GenerateKey()
-			copy(privateKey.D.Bytes(), privateKeyBytes)
+		for operatorAddr, entry := range keyStore.Keys {
+			privateKeyBytes, _ := hex.DecodeString(entry.PrivKey)
+			privateKey, err := crypto.ToECDSA(privateKeyBytes)
+			if err != nil {
+				log.Printf("  failed to recover key for %s: %v", operatorAddr, err)
+				continue
+			}
 			pubKeyBytes := keys.PublicKeyToBytes(&privateKey.PublicKey)
 			fmt.Printf("Operator: %s\n", operatorAddr)
-			fmt.Printf("  Private Key: %s\n", privateKeyHex)
+			fmt.Printf("  Private Key: %s\n", entry.PrivKey)
 			fmt.Printf("  Public Key:  %s\n", hex.EncodeToString(pubKeyBytes))
 			fmt.Println()
 		}
 		return
 	}
 
+	passphrase, err := loadPassphrase(*passphraseFile)
+	if err != nil {
+		log.Fatalf("Failed to load passphrase: %v", err)
+	}
+
+	var authVerifier *auth.Verifier
+	if *trustJWKS != "" {
+		authVerifier, err = auth.LoadVerifier(*trustJWKS, *jwtAudience)
+		if err != nil {
+			log.Fatalf("Failed to load trust JWKS: %v", err)
+		}
+	}
+
 	// Create and start server
-	server, err := NewServer(*keyStorePath, *port)
+	server, err := NewServer(*keyStorePath, *port, passphrase, *auditLogDir, authVerifier)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	"github.com/enshrined-relayers/signing-daemon/internal/auth"
+	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+)
+
+// runMintToken implements the "mint-token" CLI subcommand: it generates a
+// fresh authorization key pair, signs a test /sign bearer token with it,
+// and prints both the token and a JWKS entry the operator can drop into
+// their --trust-jwks file to accept it.
+func runMintToken(args []string) {
+	flags := flag.NewFlagSet("mint-token", flag.ExitOnError)
+	alg := flags.String("alg", "ES256", "JWS algorithm for the test token: ES256 or EdDSA")
+	digestHex := flags.String("digest", "", "digest (or message) hex the token authorizes, matching the /sign request")
+	audience := flags.String("aud", "signing-daemon", "aud claim to embed")
+	ttl := flags.Duration("ttl", 5*time.Minute, "token validity duration")
+	flags.Parse(args)
+
+	if *digestHex == "" {
+		log.Fatal("--digest is required")
+	}
+
+	tokenString, jwk, err := mintTestToken(*alg, *digestHex, *audience, *ttl)
+	if err != nil {
+		log.Fatalf("Failed to mint test token: %v", err)
+	}
+
+	fmt.Println("Bearer token:")
+	fmt.Println(tokenString)
+	fmt.Println()
+
+	jwkJSON, err := json.MarshalIndent(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{*jwk}}, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode JWKS entry: %v", err)
+	}
+	fmt.Println("Add this to your --trust-jwks file:")
+	fmt.Println(string(jwkJSON))
+}
+
+// mintTestToken generates a fresh key pair for alg, signs a /sign-shaped
+// token carrying digestHex/audience/ttl, and returns the compact-serialized
+// token along with the public JWKS entry that verifies it.
+func mintTestToken(alg, digestHex, audience string, ttl time.Duration) (string, *jose.JSONWebKey, error) {
+	var signingKey interface{}
+	var publicKey crypto.PublicKey
+	var signatureAlg jose.SignatureAlgorithm
+
+	switch alg {
+	case "ES256":
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate ES256 key: %w", err)
+		}
+		signingKey, publicKey, signatureAlg = priv, &priv.PublicKey, jose.ES256
+	case "EdDSA":
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate EdDSA key: %w", err)
+		}
+		signingKey, publicKey, signatureAlg = priv, pub, jose.EdDSA
+	default:
+		return "", nil, fmt.Errorf("unsupported alg %q: must be ES256 or EdDSA", alg)
+	}
+
+	kid := keys.LibtrustFingerprint(publicKey)
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: signatureAlg, Key: signingKey},
+		(&jose.SignerOptions{}).WithHeader("kid", kid).WithType("JWT"),
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build signer: %w", err)
+	}
+
+	now := time.Now()
+	claims := auth.Claims{
+		Claims: jwt.Claims{
+			Audience:  jwt.Audience{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Digest: digestHex,
+	}
+
+	tokenString, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	jwk := &jose.JSONWebKey{Key: publicKey, KeyID: kid, Algorithm: string(signatureAlg), Use: "sig"}
+
+	return tokenString, jwk, nil
+}
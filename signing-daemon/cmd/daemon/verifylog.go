@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+	"github.com/enshrined-relayers/signing-daemon/internal/transparency"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// runVerifyLog implements the "verify-log" CLI subcommand: an independent
+// auditor (not necessarily the daemon operator) walks a local copy of the
+// transparency log, recomputes its root, and checks it against the
+// running daemon's signed tree head, so operators can confirm the daemon
+// hasn't silently shown them a signature it never logged.
+func runVerifyLog(args []string) {
+	flags := flag.NewFlagSet("verify-log", flag.ExitOnError)
+	auditLogDir := flags.String("audit-log-dir", "audit-log", "Path to the local copy of the transparency log directory to recompute the root from")
+	daemonURL := flags.String("daemon-url", "http://localhost:8080", "Base URL of the running signing daemon, to fetch the current signed tree head from")
+	logPubkeyHex := flags.String("log-pubkey-hex", "", "Hex-encoded log public key to verify the signed tree head against")
+	logAlg := flags.String("log-alg", keys.AlgoSecp256k1, "Algorithm of the log key: secp256k1 or ed25519")
+	flags.Parse(args)
+
+	if *logPubkeyHex == "" {
+		log.Fatal("--log-pubkey-hex is required")
+	}
+
+	store, err := transparency.Open(*auditLogDir)
+	if err != nil {
+		log.Fatalf("Failed to open local transparency log: %v", err)
+	}
+	rootHash, size := store.Root()
+	fmt.Printf("Recomputed root over %d leaves: %s\n", size, hex.EncodeToString(rootHash[:]))
+
+	resp, err := http.Get(*daemonURL + "/log/sth")
+	if err != nil {
+		log.Fatalf("Failed to fetch signed tree head: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Fatalf("Failed to read signed tree head response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("Daemon returned %s fetching signed tree head: %s", resp.Status, body)
+	}
+
+	var sth transparency.SignedTreeHead
+	if err := json.Unmarshal(body, &sth); err != nil {
+		log.Fatalf("Failed to parse signed tree head response: %v", err)
+	}
+
+	if sth.TreeSize != size {
+		log.Fatalf("Signed tree head size %d does not match local log size %d; fetch the latest log before verifying", sth.TreeSize, size)
+	}
+	if sth.RootHash != hex.EncodeToString(rootHash[:]) {
+		log.Fatalf("Signed tree head root hash does not match the recomputed root: the daemon signed a different log than what's on disk")
+	}
+
+	pubKey, err := decodeLogPubKey(*logAlg, *logPubkeyHex)
+	if err != nil {
+		log.Fatalf("Failed to decode log public key: %v", err)
+	}
+	if err := transparency.VerifySTH(pubKey, &sth); err != nil {
+		log.Fatalf("Signed tree head signature verification failed: %v", err)
+	}
+
+	fmt.Println("OK: recomputed root matches the signed tree head and its signature verifies.")
+}
+
+// decodeLogPubKey parses a hex-encoded public key for alg into a
+// keys.PubKey, for verifying an externally-supplied signed tree head.
+func decodeLogPubKey(alg, hexKey string) (keys.PubKey, error) {
+	raw, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+
+	switch alg {
+	case keys.AlgoSecp256k1:
+		pub, err := crypto.DecompressPubkey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+		}
+		return keys.NewSecp256k1PubKey(pub), nil
+	case keys.AlgoEd25519:
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("ed25519 public key must be %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+		}
+		return keys.NewEd25519PubKey(ed25519.PublicKey(raw)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log key algorithm: %s", alg)
+	}
+}
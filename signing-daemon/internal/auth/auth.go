@@ -0,0 +1,145 @@
+// Package auth verifies the bearer JWTs that protect the signing daemon's
+// /sign endpoint against a fixed, operator-configured set of trusted
+// authorization keys.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+)
+
+// allowedAlgorithms are the only JOSE signature algorithms a signing token
+// will be accepted under; this also rules out the unsecured "none" alg.
+var allowedAlgorithms = map[jose.SignatureAlgorithm]bool{
+	jose.ES256: true,
+	jose.EdDSA: true,
+}
+
+// Claims is the set of claims a signing token must carry. Digest binds the
+// token to the specific digest/message it authorizes, so a captured token
+// can't be replayed against a different signing request.
+type Claims struct {
+	jwt.Claims
+	Digest string `json:"digest,omitempty"`
+}
+
+// Verifier validates bearer JWTs against a fixed set of trusted
+// authorization public keys, loaded once from a JWKS file at startup. A
+// token's JOSE header kid must equal the libtrust fingerprint of the
+// trusted key that signed it, so the JWKS file's own "kid" field (if any)
+// is ignored.
+type Verifier struct {
+	trustedKeys []jose.JSONWebKey
+	audience    string
+}
+
+// LoadVerifier reads a JWKS file from jwksPath and returns a Verifier that
+// accepts tokens signed by any key in it, audienced to audience.
+func LoadVerifier(jwksPath, audience string) (*Verifier, error) {
+	data, err := os.ReadFile(jwksPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust JWKS file: %w", err)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to parse trust JWKS file: %w", err)
+	}
+	if len(keySet.Keys) == 0 {
+		return nil, fmt.Errorf("trust JWKS file %s contains no keys", jwksPath)
+	}
+
+	return &Verifier{trustedKeys: keySet.Keys, audience: audience}, nil
+}
+
+// VerifyAndParse parses rawToken, rejects disallowed algorithms, verifies
+// its signature against the trusted key whose libtrust fingerprint matches
+// the header's kid, and validates exp/nbf/aud. It does not check the
+// digest claim; callers needing replay protection should use VerifyDigest.
+func (v *Verifier) VerifyAndParse(rawToken string) (*Claims, error) {
+	token, err := jwt.ParseSigned(rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, fmt.Errorf("expected exactly one JOSE header, got %d", len(token.Headers))
+	}
+
+	header := token.Headers[0]
+	if !allowedAlgorithms[jose.SignatureAlgorithm(header.Algorithm)] {
+		return nil, fmt.Errorf("disallowed signature algorithm: %s", header.Algorithm)
+	}
+
+	jwk, err := v.lookupKey(header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := token.Claims(jwk.Key, &claims); err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	// jwt.Claims.Validate treats a missing exp/nbf as "no constraint" rather
+	// than a violation, so a token minted without them would be valid
+	// forever. Require both explicitly before deferring to Validate for the
+	// actual time/audience comparison.
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("claim validation failed: token has no exp claim")
+	}
+	if claims.NotBefore == nil {
+		return nil, fmt.Errorf("claim validation failed: token has no nbf claim")
+	}
+
+	if err := claims.Validate(jwt.Expected{
+		Audience: jwt.Audience{v.audience},
+		Time:     time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("claim validation failed: %w", err)
+	}
+
+	return &claims, nil
+}
+
+// VerifyDigest verifies rawToken like VerifyAndParse, additionally
+// requiring its "digest" claim to equal expectedDigestHex so a token minted
+// for one digest can't be replayed against a different one.
+func (v *Verifier) VerifyDigest(rawToken, expectedDigestHex string) (*Claims, error) {
+	claims, err := v.VerifyAndParse(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(claims.Digest, expectedDigestHex) {
+		return nil, fmt.Errorf("token digest claim does not match request")
+	}
+	return claims, nil
+}
+
+// lookupKey finds the trusted key whose libtrust fingerprint equals kid.
+func (v *Verifier) lookupKey(kid string) (*jose.JSONWebKey, error) {
+	for i := range v.trustedKeys {
+		if keys.LibtrustFingerprint(v.trustedKeys[i].Key) == kid {
+			return &v.trustedKeys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no trusted key matches kid: %s", kid)
+}
+
+// BearerToken extracts the raw JWT from an Authorization: Bearer header.
+func BearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", fmt.Errorf("missing bearer token")
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
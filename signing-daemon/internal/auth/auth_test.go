@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+)
+
+func writeTestJWKS(t *testing.T, pub *ecdsa.PublicKey, kid string) string {
+	t.Helper()
+
+	keySet := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: pub, KeyID: kid, Algorithm: string(jose.ES256), Use: "sig"},
+		},
+	}
+	data, err := json.Marshal(keySet)
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "trust.jwks.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	return path
+}
+
+func signTestToken(t *testing.T, priv *ecdsa.PrivateKey, kid, audience, digest string, expiry time.Time) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: priv},
+		(&jose.SignerOptions{}).WithHeader("kid", kid).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("failed to build signer: %v", err)
+	}
+
+	now := time.Now()
+	claims := Claims{
+		Claims: jwt.Claims{
+			Audience:  jwt.Audience{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(expiry),
+		},
+		Digest: digest,
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestVerifierVerifyDigest(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	kid := keys.LibtrustFingerprint(&priv.PublicKey)
+
+	jwksPath := writeTestJWKS(t, &priv.PublicKey, kid)
+	verifier, err := LoadVerifier(jwksPath, "signing-daemon")
+	if err != nil {
+		t.Fatalf("LoadVerifier failed: %v", err)
+	}
+
+	digest := "deadbeef"
+	token := signTestToken(t, priv, kid, "signing-daemon", digest, time.Now().Add(time.Minute))
+
+	if _, err := verifier.VerifyDigest(token, digest); err != nil {
+		t.Fatalf("expected valid token to verify, got: %v", err)
+	}
+
+	if _, err := verifier.VerifyDigest(token, "0badf00d"); err == nil {
+		t.Fatalf("expected digest mismatch to fail verification")
+	}
+}
+
+func TestVerifierRejectsExpiredToken(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	kid := keys.LibtrustFingerprint(&priv.PublicKey)
+
+	jwksPath := writeTestJWKS(t, &priv.PublicKey, kid)
+	verifier, err := LoadVerifier(jwksPath, "signing-daemon")
+	if err != nil {
+		t.Fatalf("LoadVerifier failed: %v", err)
+	}
+
+	digest := "deadbeef"
+	token := signTestToken(t, priv, kid, "signing-daemon", digest, time.Now().Add(-time.Minute))
+
+	if _, err := verifier.VerifyDigest(token, digest); err == nil {
+		t.Fatalf("expected expired token to fail verification")
+	}
+}
+
+func TestVerifierRejectsTokenWithoutExpiry(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	kid := keys.LibtrustFingerprint(&priv.PublicKey)
+
+	jwksPath := writeTestJWKS(t, &priv.PublicKey, kid)
+	verifier, err := LoadVerifier(jwksPath, "signing-daemon")
+	if err != nil {
+		t.Fatalf("LoadVerifier failed: %v", err)
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: priv},
+		(&jose.SignerOptions{}).WithHeader("kid", kid).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("failed to build signer: %v", err)
+	}
+
+	digest := "deadbeef"
+	now := time.Now()
+	claims := Claims{
+		Claims: jwt.Claims{
+			Audience:  jwt.Audience{"signing-daemon"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			// Expiry deliberately omitted: such a token would otherwise be
+			// valid forever under go-jose's "no constraint if absent" rule.
+		},
+		Digest: digest,
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	if _, err := verifier.VerifyDigest(token, digest); err == nil {
+		t.Fatalf("expected token without an exp claim to fail verification")
+	}
+}
+
+func TestVerifierRejectsDisallowedAlgorithm(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	kid := keys.LibtrustFingerprint(&priv.PublicKey)
+
+	jwksPath := writeTestJWKS(t, &priv.PublicKey, kid)
+	verifier, err := LoadVerifier(jwksPath, "signing-daemon")
+	if err != nil {
+		t.Fatalf("LoadVerifier failed: %v", err)
+	}
+
+	// HS256 is a symmetric algorithm never present in allowedAlgorithms;
+	// it must be rejected on the header alone, before any key lookup or
+	// claim validation.
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.HS256, Key: []byte("shared-secret-shared-secret-32b")},
+		(&jose.SignerOptions{}).WithHeader("kid", kid).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("failed to build HS256 signer: %v", err)
+	}
+
+	now := time.Now()
+	digest := "deadbeef"
+	claims := Claims{
+		Claims: jwt.Claims{
+			Audience:  jwt.Audience{"signing-daemon"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		Digest: digest,
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	if _, err := verifier.VerifyDigest(token, digest); err == nil {
+		t.Fatalf("expected an HS256 token to be rejected as a disallowed algorithm")
+	}
+}
+
+func TestVerifierRejectsUntrustedKey(t *testing.T) {
+	trusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	jwksPath := writeTestJWKS(t, &trusted.PublicKey, keys.LibtrustFingerprint(&trusted.PublicKey))
+	verifier, err := LoadVerifier(jwksPath, "signing-daemon")
+	if err != nil {
+		t.Fatalf("LoadVerifier failed: %v", err)
+	}
+
+	digest := "deadbeef"
+	kid := keys.LibtrustFingerprint(&untrusted.PublicKey)
+	token := signTestToken(t, untrusted, kid, "signing-daemon", digest, time.Now().Add(time.Minute))
+
+	if _, err := verifier.VerifyDigest(token, digest); err == nil {
+		t.Fatalf("expected token from untrusted key to fail verification")
+	}
+}
@@ -0,0 +1,126 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+)
+
+func TestEd25519PrivKeySignVerify(t *testing.T) {
+	privKey, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+
+	priv := NewEd25519PrivKey(privKey)
+	if priv.Type() != AlgoEd25519 {
+		t.Fatalf("expected type %q, got %q", AlgoEd25519, priv.Type())
+	}
+
+	msg := []byte("sign the raw message, not a digest")
+	sig, err := priv.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(sig) != ed25519.SignatureSize {
+		t.Fatalf("expected a %d-byte signature, got %d", ed25519.SignatureSize, len(sig))
+	}
+
+	pub := priv.PubKey()
+	if pub.Type() != AlgoEd25519 {
+		t.Fatalf("expected pubkey type %q, got %q", AlgoEd25519, pub.Type())
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub.Bytes()), msg, sig) {
+		t.Fatalf("signature failed verification")
+	}
+
+	if ed25519.Verify(ed25519.PublicKey(pub.Bytes()), []byte("a different message"), sig) {
+		t.Fatalf("signature verified against the wrong message")
+	}
+}
+
+func TestGetPrivKeyAlgoDispatch(t *testing.T) {
+	ks := &KeyStore{Keys: make(map[string]*KeyEntry)}
+
+	secpKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate secp256k1 key: %v", err)
+	}
+	if err := ks.AddKey("orgvaloper-secp256k1", PrivateKeyToHex(secpKey)); err != nil {
+		t.Fatalf("AddKey failed: %v", err)
+	}
+
+	edKey, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	if err := ks.AddKeyEd25519("orgvaloper-ed25519", edKey); err != nil {
+		t.Fatalf("AddKeyEd25519 failed: %v", err)
+	}
+
+	secpPriv, err := ks.GetPrivKey("orgvaloper-secp256k1", "")
+	if err != nil {
+		t.Fatalf("GetPrivKey failed for secp256k1 entry: %v", err)
+	}
+	if secpPriv.Type() != AlgoSecp256k1 {
+		t.Fatalf("expected %q, got %q", AlgoSecp256k1, secpPriv.Type())
+	}
+	if _, ok := secpPriv.(*Secp256k1PrivKey); !ok {
+		t.Fatalf("expected a *Secp256k1PrivKey, got %T", secpPriv)
+	}
+
+	edPriv, err := ks.GetPrivKey("orgvaloper-ed25519", "")
+	if err != nil {
+		t.Fatalf("GetPrivKey failed for ed25519 entry: %v", err)
+	}
+	if edPriv.Type() != AlgoEd25519 {
+		t.Fatalf("expected %q, got %q", AlgoEd25519, edPriv.Type())
+	}
+	if _, ok := edPriv.(*Ed25519PrivKey); !ok {
+		t.Fatalf("expected an *Ed25519PrivKey, got %T", edPriv)
+	}
+}
+
+func TestAminoPubKeyBytesPrefixAndLength(t *testing.T) {
+	secpKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate secp256k1 key: %v", err)
+	}
+	secpPub := NewSecp256k1PubKey(&secpKey.PublicKey)
+
+	secpEncoded, err := AminoPubKeyBytes(secpPub)
+	if err != nil {
+		t.Fatalf("AminoPubKeyBytes failed for secp256k1: %v", err)
+	}
+	if !bytes.Equal(secpEncoded[:4], aminoPrefixSecp256k1) {
+		t.Fatalf("expected secp256k1 prefix %x, got %x", aminoPrefixSecp256k1, secpEncoded[:4])
+	}
+	wantSecpLen := 4 + 1 + len(secpPub.Bytes())
+	if len(secpEncoded) != wantSecpLen {
+		t.Fatalf("expected encoded length %d, got %d", wantSecpLen, len(secpEncoded))
+	}
+	if int(secpEncoded[4]) != len(secpPub.Bytes()) {
+		t.Fatalf("length byte %d does not match raw key length %d", secpEncoded[4], len(secpPub.Bytes()))
+	}
+
+	edPriv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	edPub := NewEd25519PubKey(edPriv.Public().(ed25519.PublicKey))
+
+	edEncoded, err := AminoPubKeyBytes(edPub)
+	if err != nil {
+		t.Fatalf("AminoPubKeyBytes failed for ed25519: %v", err)
+	}
+	if !bytes.Equal(edEncoded[:4], aminoPrefixEd25519) {
+		t.Fatalf("expected ed25519 prefix %x, got %x", aminoPrefixEd25519, edEncoded[:4])
+	}
+	wantEdLen := 4 + 1 + ed25519.PublicKeySize
+	if len(edEncoded) != wantEdLen {
+		t.Fatalf("expected encoded length %d, got %d", wantEdLen, len(edEncoded))
+	}
+	if int(edEncoded[4]) != ed25519.PublicKeySize {
+		t.Fatalf("length byte %d does not match %d", edEncoded[4], ed25519.PublicKeySize)
+	}
+}
@@ -0,0 +1,168 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Default scrypt parameters, matching the Ethereum Web3 Secret Storage
+// recommendation for interactive use.
+const (
+	scryptN     = 1 << 18 // 262144
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+)
+
+// CipherParamsJSON holds the parameters for the symmetric cipher used to
+// encrypt a private key.
+type CipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// ScryptParamsJSON holds the scrypt KDF parameters, including the salt used
+// to derive the encryption/MAC key from a passphrase.
+type ScryptParamsJSON struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// CryptoJSON is the Web3 Secret Storage v3 "crypto" object: everything
+// needed to recover a private key from a passphrase.
+type CryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams CipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    ScryptParamsJSON `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+// encryptKey encrypts privateKeyBytes under passphrase using scrypt + AES-128-CTR,
+// producing a Web3 Secret Storage v3 "crypto" object.
+func encryptKey(privateKeyBytes []byte, passphrase string) (*CryptoJSON, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("scrypt key derivation failed: %w", err)
+	}
+	defer zero(derivedKey)
+
+	encryptKey, macKey := derivedKey[:16], derivedKey[16:]
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("failed to generate iv: %w", err)
+	}
+
+	block, err := aes.NewCipher(encryptKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aes cipher: %w", err)
+	}
+
+	cipherText := make([]byte, len(privateKeyBytes))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, privateKeyBytes)
+
+	mac := crypto.Keccak256(append(macKey, cipherText...))
+
+	return &CryptoJSON{
+		Cipher:     "aes-128-ctr",
+		CipherText: hex.EncodeToString(cipherText),
+		CipherParams: CipherParamsJSON{
+			IV: hex.EncodeToString(iv),
+		},
+		KDF: "scrypt",
+		KDFParams: ScryptParamsJSON{
+			N:     scryptN,
+			R:     scryptR,
+			P:     scryptP,
+			DKLen: scryptDKLen,
+			Salt:  hex.EncodeToString(salt),
+		},
+		MAC: hex.EncodeToString(mac),
+	}, nil
+}
+
+// decryptKey recovers the plaintext private key bytes from a CryptoJSON
+// object given the passphrase, verifying the MAC before decrypting.
+func decryptKey(c *CryptoJSON, passphrase string) ([]byte, error) {
+	if c.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher: %s", c.Cipher)
+	}
+	if c.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported kdf: %s", c.KDF)
+	}
+
+	salt, err := hex.DecodeString(c.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %w", err)
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mac: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, c.KDFParams.N, c.KDFParams.R, c.KDFParams.P, c.KDFParams.DKLen)
+	if err != nil {
+		return nil, fmt.Errorf("scrypt key derivation failed: %w", err)
+	}
+	defer zero(derivedKey)
+
+	encryptKeyBytes, macKey := derivedKey[:16], derivedKey[16:]
+
+	calculatedMAC := crypto.Keccak256(append(macKey, cipherText...))
+	if !bytesEqual(calculatedMAC, mac) {
+		return nil, fmt.Errorf("invalid passphrase: mac mismatch")
+	}
+
+	block, err := aes.NewCipher(encryptKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aes cipher: %w", err)
+	}
+
+	plainText := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(plainText, cipherText)
+
+	return plainText, nil
+}
+
+// zero overwrites b with zero bytes, for clearing sensitive material from
+// memory once it is no longer needed.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
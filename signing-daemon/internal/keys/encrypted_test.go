@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEncryptDecryptKeyRoundTrip(t *testing.T) {
+	privateKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	privateKeyBytes := crypto.FromECDSA(privateKey)
+
+	cryptoJSON, err := encryptKey(privateKeyBytes, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("encryptKey failed: %v", err)
+	}
+
+	decrypted, err := decryptKey(cryptoJSON, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("decryptKey failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, privateKeyBytes) {
+		t.Fatalf("round-tripped key does not match original")
+	}
+}
+
+func TestDecryptKeyWrongPassphraseRejected(t *testing.T) {
+	privateKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cryptoJSON, err := encryptKey(crypto.FromECDSA(privateKey), "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("encryptKey failed: %v", err)
+	}
+
+	if _, err := decryptKey(cryptoJSON, "wrong passphrase"); err == nil {
+		t.Fatalf("decryptKey succeeded with the wrong passphrase")
+	}
+}
+
+func TestDecryptKeyTamperedCiphertextRejected(t *testing.T) {
+	privateKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cryptoJSON, err := encryptKey(crypto.FromECDSA(privateKey), "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("encryptKey failed: %v", err)
+	}
+
+	cipherText, err := hex.DecodeString(cryptoJSON.CipherText)
+	if err != nil {
+		t.Fatalf("failed to decode ciphertext: %v", err)
+	}
+	cipherText[0] ^= 0xff
+	cryptoJSON.CipherText = hex.EncodeToString(cipherText)
+
+	if _, err := decryptKey(cryptoJSON, "correct horse battery staple"); err == nil {
+		t.Fatalf("decryptKey succeeded against a tampered ciphertext")
+	}
+}
+
+func TestDecryptKeyTamperedMACRejected(t *testing.T) {
+	privateKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cryptoJSON, err := encryptKey(crypto.FromECDSA(privateKey), "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("encryptKey failed: %v", err)
+	}
+
+	mac, err := hex.DecodeString(cryptoJSON.MAC)
+	if err != nil {
+		t.Fatalf("failed to decode mac: %v", err)
+	}
+	mac[0] ^= 0xff
+	cryptoJSON.MAC = hex.EncodeToString(mac)
+
+	if _, err := decryptKey(cryptoJSON, "correct horse battery staple"); err == nil {
+		t.Fatalf("decryptKey succeeded against a tampered mac")
+	}
+}
+
+func TestMigrateToEncryptedPreservesAlgoAndRole(t *testing.T) {
+	ks := &KeyStore{Keys: make(map[string]*KeyEntry)}
+
+	ed25519Priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	if err := ks.AddKeyEd25519("orgvaloper-ed25519", ed25519Priv); err != nil {
+		t.Fatalf("AddKeyEd25519 failed: %v", err)
+	}
+
+	logKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	ks.Keys["orgvaloper-log"] = &KeyEntry{
+		Type:    KeyEntryTypeHex,
+		Role:    KeyEntryRoleLog,
+		PrivKey: PrivateKeyToHex(logKey),
+	}
+
+	if err := ks.MigrateToEncrypted("passphrase"); err != nil {
+		t.Fatalf("MigrateToEncrypted failed: %v", err)
+	}
+
+	edEntry := ks.Keys["orgvaloper-ed25519"]
+	if edEntry.Type != KeyEntryTypeEncryptedV3 {
+		t.Fatalf("expected ed25519 entry to be migrated, got type %q", edEntry.Type)
+	}
+	if edEntry.Algo != AlgoEd25519 {
+		t.Fatalf("expected migrated entry to keep Algo %q, got %q", AlgoEd25519, edEntry.Algo)
+	}
+
+	logEntry := ks.Keys["orgvaloper-log"]
+	if logEntry.Role != KeyEntryRoleLog {
+		t.Fatalf("expected migrated entry to keep Role %q, got %q", KeyEntryRoleLog, logEntry.Role)
+	}
+
+	if operator, ok := ks.LogSignerOperator(); !ok || operator != "orgvaloper-log" {
+		t.Fatalf("LogSignerOperator could not find the migrated log key (ok=%v, operator=%q)", ok, operator)
+	}
+
+	privKey, err := ks.GetPrivKey("orgvaloper-ed25519", "passphrase")
+	if err != nil {
+		t.Fatalf("GetPrivKey failed on migrated ed25519 entry: %v", err)
+	}
+	if privKey.Type() != AlgoEd25519 {
+		t.Fatalf("expected migrated key to still decode as ed25519, got %q", privKey.Type())
+	}
+}
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -12,9 +13,52 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 )
 
+// KeyEntryTypeHex marks a keystore entry holding a plaintext private key hex
+// string. It exists for backwards compatibility with unencrypted key
+// stores; AddKeyEncrypted should be preferred for anything touching real
+// funds.
+const KeyEntryTypeHex = "hex"
+
+// KeyEntryTypeEncryptedV3 marks a keystore entry encrypted at rest using
+// the Ethereum Web3 Secret Storage v3 format (scrypt + AES-128-CTR).
+const KeyEntryTypeEncryptedV3 = "encrypted-v3"
+
+// KeyEntryTypeLedger marks a keystore entry whose key material lives on a
+// Ledger hardware wallet, identified by a BIP-32 derivation Path.
+const KeyEntryTypeLedger = "ledger"
+
+// KeyEntryRoleLog marks a keystore entry as the dedicated key used to sign
+// the transparency log's tree heads, rather than to sign on behalf of an
+// operator.
+const KeyEntryRoleLog = "log"
+
+// KeyEntry is a single entry in a KeyStore. Type is a tagged union over how
+// the key is stored: "hex" entries carry a plaintext PrivKey,
+// "encrypted-v3" entries carry a Crypto object that must be decrypted with
+// a passphrase before use, and "ledger" entries carry a BIP-32 Path
+// identifying the key on a connected hardware device. Algo names the
+// signing algorithm the stored key material is for (AlgoSecp256k1 if
+// empty, for backwards compatibility, or AlgoEd25519); it's independent of
+// Type and orthogonal to it, except that "ledger" entries are always
+// secp256k1. Role is empty for ordinary operator keys or KeyEntryRoleLog
+// for the transparency log's signing key; it's independent of both Type
+// and Algo. PubKeyHex is the expected compressed secp256k1 public key for
+// a "ledger" entry (hex-encoded); when set, it's checked against whatever
+// device ends up bound to this entry so a device mismatch fails closed
+// instead of silently signing as the wrong operator.
+type KeyEntry struct {
+	Type      string      `json:"type"`
+	Algo      string      `json:"algo,omitempty"`
+	Role      string      `json:"role,omitempty"`
+	PrivKey   string      `json:"privKey,omitempty"`
+	Crypto    *CryptoJSON `json:"crypto,omitempty"`
+	Path      string      `json:"path,omitempty"`
+	PubKeyHex string      `json:"pubKeyHex,omitempty"`
+}
+
 // KeyStore represents a collection of validator keys
 type KeyStore struct {
-	Keys map[string]string `json:"keys"` // operator address -> private key hex
+	Keys map[string]*KeyEntry `json:"keys"` // operator address -> key entry
 }
 
 // LoadKeyStore loads a key store from a JSON file
@@ -46,30 +90,96 @@ func (ks *KeyStore) SaveKeyStore(filename string) error {
 	return nil
 }
 
-// GetPrivateKey retrieves a private key for the given operator address
-func (ks *KeyStore) GetPrivateKey(operatorAddr string) (*ecdsa.PrivateKey, error) {
-	privateKeyHex, exists := ks.Keys[operatorAddr]
+// GetPrivateKey retrieves a secp256k1 private key for the given operator
+// address, decrypting it with passphrase if the entry is encrypted at
+// rest. passphrase is ignored for plaintext "hex" entries. It is a thin
+// secp256k1-only convenience wrapper around GetPrivKey for callers (e.g.
+// Ethereum-specific tooling) that want a raw *ecdsa.PrivateKey rather than
+// the generic PrivKey interface.
+func (ks *KeyStore) GetPrivateKey(operatorAddr, passphrase string) (*ecdsa.PrivateKey, error) {
+	privKey, err := ks.GetPrivKey(operatorAddr, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	secpKey, ok := privKey.(*Secp256k1PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("operator %s is not a secp256k1 key (got %s)", operatorAddr, privKey.Type())
+	}
+	return secpKey.ECDSA(), nil
+}
+
+// GetPrivKey retrieves the PrivKey for the given operator address,
+// decrypting it with passphrase if the entry is encrypted at rest and
+// dispatching to the right algorithm based on the entry's Algo. passphrase
+// is ignored for plaintext "hex" entries. Any intermediate plaintext
+// material is zeroed once the PrivKey has been built.
+func (ks *KeyStore) GetPrivKey(operatorAddr, passphrase string) (PrivKey, error) {
+	entry, exists := ks.Keys[operatorAddr]
 	if !exists {
 		return nil, fmt.Errorf("private key not found for operator: %s", operatorAddr)
 	}
 
-	privateKeyBytes, err := hex.DecodeString(privateKeyHex)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key hex: %w", err)
+	var keyBytes []byte
+	switch entry.Type {
+	case "", KeyEntryTypeHex:
+		b, err := hex.DecodeString(entry.PrivKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key hex: %w", err)
+		}
+		keyBytes = b
+	case KeyEntryTypeEncryptedV3:
+		if entry.Crypto == nil {
+			return nil, fmt.Errorf("encrypted-v3 entry missing crypto object for operator: %s", operatorAddr)
+		}
+		b, err := decryptKey(entry.Crypto, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key for operator %s: %w", operatorAddr, err)
+		}
+		keyBytes = b
+	default:
+		return nil, fmt.Errorf("unsupported key entry type %q for operator: %s", entry.Type, operatorAddr)
 	}
+	defer zero(keyBytes)
 
-	privateKey, err := crypto.ToECDSA(privateKeyBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	algo := entry.Algo
+	if algo == "" {
+		algo = AlgoSecp256k1
 	}
 
-	return privateKey, nil
+	switch algo {
+	case AlgoSecp256k1:
+		privateKey, err := crypto.ToECDSA(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		return NewSecp256k1PrivKey(privateKey), nil
+	case AlgoEd25519:
+		if len(keyBytes) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("ed25519 private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(keyBytes))
+		}
+		return NewEd25519PrivKey(ed25519.PrivateKey(append([]byte(nil), keyBytes...))), nil
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q for operator: %s", algo, operatorAddr)
+	}
 }
 
-// AddKey adds a new private key for an operator
+// LogSignerOperator returns the operator key of the keystore entry flagged
+// KeyEntryRoleLog, i.e. the key that signs the transparency log's tree
+// heads, and whether one was found.
+func (ks *KeyStore) LogSignerOperator() (string, bool) {
+	for operatorAddr, entry := range ks.Keys {
+		if entry.Role == KeyEntryRoleLog {
+			return operatorAddr, true
+		}
+	}
+	return "", false
+}
+
+// AddKey adds a new plaintext private key for an operator
 func (ks *KeyStore) AddKey(operatorAddr string, privateKeyHex string) error {
 	if ks.Keys == nil {
-		ks.Keys = make(map[string]string)
+		ks.Keys = make(map[string]*KeyEntry)
 	}
 
 	// Validate the private key
@@ -78,7 +188,73 @@ func (ks *KeyStore) AddKey(operatorAddr string, privateKeyHex string) error {
 		return fmt.Errorf("invalid private key hex: %w", err)
 	}
 
-	ks.Keys[operatorAddr] = privateKeyHex
+	ks.Keys[operatorAddr] = &KeyEntry{Type: KeyEntryTypeHex, PrivKey: privateKeyHex}
+	return nil
+}
+
+// AddKeyEd25519 adds a new plaintext Ed25519 private key for an operator.
+func (ks *KeyStore) AddKeyEd25519(operatorAddr string, privateKey ed25519.PrivateKey) error {
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]*KeyEntry)
+	}
+
+	ks.Keys[operatorAddr] = &KeyEntry{
+		Type:    KeyEntryTypeHex,
+		Algo:    AlgoEd25519,
+		PrivKey: hex.EncodeToString(privateKey),
+	}
+	return nil
+}
+
+// AddKeyEncrypted adds privateKey for operatorAddr, encrypting it at rest
+// under passphrase using the Web3 Secret Storage v3 format.
+func (ks *KeyStore) AddKeyEncrypted(operatorAddr string, privateKey *ecdsa.PrivateKey, passphrase string) error {
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]*KeyEntry)
+	}
+
+	privateKeyBytes := crypto.FromECDSA(privateKey)
+	defer zero(privateKeyBytes)
+
+	cryptoJSON, err := encryptKey(privateKeyBytes, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key for operator %s: %w", operatorAddr, err)
+	}
+
+	ks.Keys[operatorAddr] = &KeyEntry{Type: KeyEntryTypeEncryptedV3, Crypto: cryptoJSON}
+	return nil
+}
+
+// MigrateToEncrypted rewrites every plaintext "hex" entry in the keystore
+// to an "encrypted-v3" entry protected by passphrase, in place. The
+// original entry's Algo and Role are carried over unchanged, so Ed25519
+// operator keys and the transparency log's role:"log" key survive
+// migration intact.
+func (ks *KeyStore) MigrateToEncrypted(passphrase string) error {
+	for operatorAddr, entry := range ks.Keys {
+		if entry.Type != "" && entry.Type != KeyEntryTypeHex {
+			continue
+		}
+
+		privateKeyBytes, err := hex.DecodeString(entry.PrivKey)
+		if err != nil {
+			return fmt.Errorf("invalid private key hex for operator %s: %w", operatorAddr, err)
+		}
+
+		cryptoJSON, err := encryptKey(privateKeyBytes, passphrase)
+		zero(privateKeyBytes)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt key for operator %s: %w", operatorAddr, err)
+		}
+
+		ks.Keys[operatorAddr] = &KeyEntry{
+			Type:   KeyEntryTypeEncryptedV3,
+			Algo:   entry.Algo,
+			Role:   entry.Role,
+			Crypto: cryptoJSON,
+		}
+	}
+
 	return nil
 }
 
@@ -127,7 +303,7 @@ func VerifySignature(publicKey *ecdsa.PublicKey, digest, signature []byte) bool
 // CreateSampleKeyStore creates a sample key store with test keys
 func CreateSampleKeyStore() *KeyStore {
 	ks := &KeyStore{
-		Keys: make(map[string]string),
+		Keys: make(map[string]*KeyEntry),
 	}
 
 	// Generate some sample keys for testing
@@ -143,7 +319,7 @@ func CreateSampleKeyStore() *KeyStore {
 		if err != nil {
 			continue
 		}
-		ks.Keys[operator] = PrivateKeyToHex(privateKey)
+		ks.Keys[operator] = &KeyEntry{Type: KeyEntryTypeHex, PrivKey: PrivateKeyToHex(privateKey)}
 	}
 
 	return ks
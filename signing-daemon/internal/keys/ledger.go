@@ -0,0 +1,296 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/karalabe/hid"
+)
+
+// Ledger USB vendor ID, shared by Nano S/S Plus/X.
+const ledgerVendorID = 0x2c97
+
+// Ethereum app APDU instruction codes.
+const (
+	ledgerInsGetPublicKey        = 0x02
+	ledgerInsSignPersonalMessage = 0x04
+	// ledgerInsSignRawDigest is not part of the stock Ledger Ethereum app,
+	// which refuses to sign an opaque 32-byte digest. It is provided for
+	// app builds/forks that expose a "sign hash" instruction; callers that
+	// need compatibility with the stock app should hash through
+	// ledgerInsSignPersonalMessage instead.
+	ledgerInsSignRawDigest = 0x06
+)
+
+const (
+	ledgerCLA       = 0xe0
+	ledgerP1First   = 0x00
+	ledgerHIDChan   = 0x0101
+	ledgerHIDTag    = 0x05
+	ledgerHIDPacket = 64
+)
+
+// HIDDevice is the minimal transport a LedgerSigner needs. It is satisfied
+// by *hid.Device and by MockHIDTransport in tests.
+type HIDDevice interface {
+	Write(p []byte) (int, error)
+	Read(p []byte) (int, error)
+	Close() error
+}
+
+// DiscoverLedgers enumerates connected Ledger devices and opens each one.
+// Callers are responsible for closing the returned devices once done.
+func DiscoverLedgers() ([]HIDDevice, error) {
+	if !hid.Supported() {
+		return nil, fmt.Errorf("hidapi not supported on this platform")
+	}
+
+	var devices []HIDDevice
+	for _, info := range hid.Enumerate(ledgerVendorID, 0) {
+		device, err := info.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ledger device %s: %w", info.Path, err)
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// LedgerSigner is a Signer whose key material lives on a Ledger hardware
+// wallet at a fixed BIP-32 derivation path.
+type LedgerSigner struct {
+	operatorAddr string
+	path         []uint32
+	device       HIDDevice
+	pubKey       *ecdsa.PublicKey
+}
+
+// NewLedgerSigner opens a signer for operatorAddr against device, deriving
+// the key at the given BIP-32 path (e.g. "m/44'/60'/0'/0/0") and fetching
+// its public key from the device.
+func NewLedgerSigner(operatorAddr, path string, device HIDDevice) (*LedgerSigner, error) {
+	components, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+	}
+
+	signer := &LedgerSigner{
+		operatorAddr: operatorAddr,
+		path:         components,
+		device:       device,
+	}
+
+	pubKey, err := signer.fetchPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch public key from ledger for operator %s: %w", operatorAddr, err)
+	}
+	signer.pubKey = pubKey
+
+	return signer, nil
+}
+
+// OperatorAddr implements Signer.
+func (l *LedgerSigner) OperatorAddr() string {
+	return l.operatorAddr
+}
+
+// Close releases the underlying HID device.
+func (l *LedgerSigner) Close() error {
+	return l.device.Close()
+}
+
+// PubKey implements Signer. Ledger-backed keys are always secp256k1.
+func (l *LedgerSigner) PubKey() PubKey {
+	return &Secp256k1PubKey{pub: l.pubKey}
+}
+
+// Sign implements Signer, returning a 65-byte R||S||V signature produced by
+// the device without the private key ever leaving it. digest must be a
+// pre-hashed 32-byte secp256k1 digest.
+func (l *LedgerSigner) Sign(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be exactly 32 bytes, got %d", len(digest))
+	}
+
+	data := encodeDerivationPath(l.path)
+	data = append(data, digest...)
+
+	resp, err := ledgerExchange(l.device, ledgerInsSignRawDigest, data)
+	if err != nil {
+		return nil, fmt.Errorf("ledger sign exchange failed: %w", err)
+	}
+	if len(resp) != 65 {
+		return nil, fmt.Errorf("unexpected ledger signature length: %d", len(resp))
+	}
+
+	// Device returns v||r||s; the rest of the codebase expects r||s||v.
+	v, r, s := resp[0], resp[1:33], resp[33:65]
+	signature := make([]byte, 65)
+	copy(signature[0:32], r)
+	copy(signature[32:64], s)
+	signature[64] = v
+
+	return signature, nil
+}
+
+// fetchPublicKey sends INS_GET_PUBLIC_KEY and parses the uncompressed
+// public key out of the response.
+func (l *LedgerSigner) fetchPublicKey() (*ecdsa.PublicKey, error) {
+	data := encodeDerivationPath(l.path)
+
+	resp, err := ledgerExchange(l.device, ledgerInsGetPublicKey, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 1 {
+		return nil, fmt.Errorf("empty response from ledger")
+	}
+
+	pubKeyLen := int(resp[0])
+	if len(resp) < 1+pubKeyLen {
+		return nil, fmt.Errorf("truncated public key in ledger response")
+	}
+
+	pubKey, err := crypto.UnmarshalPubkey(resp[1 : 1+pubKeyLen])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ledger public key: %w", err)
+	}
+	return pubKey, nil
+}
+
+// parseDerivationPath parses a BIP-32 path string like "m/44'/60'/0'/0/0"
+// into its component indices, with the apostrophe marking a hardened
+// component (index | 0x80000000).
+func parseDerivationPath(path string) ([]uint32, error) {
+	path = strings.TrimPrefix(path, "m/")
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	parts := strings.Split(path, "/")
+	components := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		part = strings.TrimSuffix(strings.TrimSuffix(part, "'"), "h")
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path component %q: %w", part, err)
+		}
+		if hardened {
+			index |= 0x80000000
+		}
+		components = append(components, uint32(index))
+	}
+	return components, nil
+}
+
+// encodeDerivationPath encodes path components in the format the Ethereum
+// app's APDU commands expect: one byte giving the component count,
+// followed by each component as a big-endian uint32.
+func encodeDerivationPath(path []uint32) []byte {
+	data := make([]byte, 1+4*len(path))
+	data[0] = byte(len(path))
+	for i, component := range path {
+		binary.BigEndian.PutUint32(data[1+4*i:], component)
+	}
+	return data
+}
+
+// ledgerExchange builds an APDU for (ins, data), frames it over the Ledger
+// HID transport protocol, writes it to device, and returns the parsed
+// response payload (with the trailing two-byte status word stripped and
+// checked for success).
+func ledgerExchange(device HIDDevice, ins byte, data []byte) ([]byte, error) {
+	apdu := make([]byte, 5, 5+len(data))
+	apdu[0] = ledgerCLA
+	apdu[1] = ins
+	apdu[2] = ledgerP1First
+	apdu[3] = 0x00
+	apdu[4] = byte(len(data))
+	apdu = append(apdu, data...)
+
+	if err := ledgerWrite(device, apdu); err != nil {
+		return nil, err
+	}
+	resp, err := ledgerRead(device)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("short ledger response")
+	}
+	sw := binary.BigEndian.Uint16(resp[len(resp)-2:])
+	payload := resp[:len(resp)-2]
+	if sw != 0x9000 {
+		return nil, fmt.Errorf("ledger device returned status word %#04x", sw)
+	}
+	return payload, nil
+}
+
+// ledgerWrite fragments apdu into ledgerHIDPacket-sized HID packets tagged
+// with the shared channel ID, per the Ledger HID transport framing.
+func ledgerWrite(device HIDDevice, apdu []byte) error {
+	packet := make([]byte, ledgerHIDPacket)
+	for seq := 0; len(apdu) > 0 || seq == 0; seq++ {
+		for i := range packet {
+			packet[i] = 0
+		}
+		binary.BigEndian.PutUint16(packet[0:2], ledgerHIDChan)
+		packet[2] = ledgerHIDTag
+		binary.BigEndian.PutUint16(packet[3:5], uint16(seq))
+
+		offset := 5
+		if seq == 0 {
+			binary.BigEndian.PutUint16(packet[5:7], uint16(len(apdu)))
+			offset = 7
+		}
+
+		n := copy(packet[offset:], apdu)
+		apdu = apdu[n:]
+
+		if _, err := device.Write(packet); err != nil {
+			return fmt.Errorf("failed to write to ledger device: %w", err)
+		}
+		if len(apdu) == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// ledgerRead reassembles a full APDU response from one or more
+// ledgerHIDPacket-sized HID packets.
+func ledgerRead(device HIDDevice) ([]byte, error) {
+	packet := make([]byte, ledgerHIDPacket)
+	var response []byte
+	var want int
+
+	for seq := 0; ; seq++ {
+		if _, err := device.Read(packet); err != nil {
+			return nil, fmt.Errorf("failed to read from ledger device: %w", err)
+		}
+
+		offset := 5
+		if seq == 0 {
+			want = int(binary.BigEndian.Uint16(packet[5:7]))
+			offset = 7
+		}
+
+		n := want - len(response)
+		if n > len(packet)-offset {
+			n = len(packet) - offset
+		}
+		response = append(response, packet[offset:offset+n]...)
+
+		if len(response) >= want {
+			return response, nil
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base32"
+	"strings"
+)
+
+// LibtrustFingerprint computes a libtrust-style key fingerprint for pub:
+// the DER-encoded SubjectPublicKeyInfo is SHA-256 hashed, truncated to 240
+// bits, base32-encoded, and split into twelve ':'-separated four-character
+// groups (e.g. "PYYO:TEWU:V7JH:..."). It returns "" if pub's concrete type
+// can't be marshalled as a SubjectPublicKeyInfo.
+func LibtrustFingerprint(pub crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(der)
+	truncated := sum[:30] // 240 bits
+
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(truncated)
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, ":")
+}
@@ -0,0 +1,36 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"regexp"
+	"testing"
+)
+
+var libtrustFingerprintPattern = regexp.MustCompile(`^([A-Z2-7]{4}:){11}[A-Z2-7]{4}$`)
+
+func TestLibtrustFingerprintFormat(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	fingerprint := LibtrustFingerprint(&priv.PublicKey)
+	if !libtrustFingerprintPattern.MatchString(fingerprint) {
+		t.Fatalf("fingerprint %q does not match expected twelve-group format", fingerprint)
+	}
+}
+
+func TestLibtrustFingerprintDeterministic(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	a := LibtrustFingerprint(&priv.PublicKey)
+	b := LibtrustFingerprint(&priv.PublicKey)
+	if a != b {
+		t.Fatalf("fingerprint is not deterministic: %q != %q", a, b)
+	}
+}
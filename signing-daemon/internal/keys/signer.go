@@ -0,0 +1,71 @@
+package keys
+
+import (
+	"fmt"
+)
+
+// Signer abstracts over where an operator's private key material actually
+// lives: in the keystore JSON (plaintext or encrypted) or on a hardware
+// device such as a Ledger. handleSign callers should depend on this
+// interface rather than reaching for a raw PrivKey.
+type Signer interface {
+	// Sign signs msg and returns the algorithm-specific signature
+	// encoding. Callers must pass the right thing for the signer's
+	// PubKey().Type(): a pre-hashed 32-byte digest for secp256k1, the raw
+	// message for Ed25519.
+	Sign(msg []byte) ([]byte, error)
+	// PubKey returns the signer's public key.
+	PubKey() PubKey
+	// OperatorAddr returns the operator address this signer was configured for.
+	OperatorAddr() string
+}
+
+// SoftSigner is a Signer backed by an in-memory PrivKey, i.e. one loaded
+// from a "hex" or "encrypted-v3" keystore entry.
+type SoftSigner struct {
+	operatorAddr string
+	privKey      PrivKey
+}
+
+// NewSoftSigner wraps privKey as a Signer for operatorAddr.
+func NewSoftSigner(operatorAddr string, privKey PrivKey) *SoftSigner {
+	return &SoftSigner{operatorAddr: operatorAddr, privKey: privKey}
+}
+
+// Sign implements Signer.
+func (s *SoftSigner) Sign(msg []byte) ([]byte, error) {
+	return s.privKey.Sign(msg)
+}
+
+// PubKey implements Signer.
+func (s *SoftSigner) PubKey() PubKey {
+	return s.privKey.PubKey()
+}
+
+// OperatorAddr implements Signer.
+func (s *SoftSigner) OperatorAddr() string {
+	return s.operatorAddr
+}
+
+// GetSigner resolves operatorAddr to a Signer. Software-backed entries
+// ("hex", "encrypted-v3") are decrypted and wrapped in a SoftSigner here;
+// "ledger" entries cannot be resolved from the keystore alone since they
+// require a live HID device, and so return an error directing the caller
+// to the daemon's ledger signer registry instead.
+func (ks *KeyStore) GetSigner(operatorAddr, passphrase string) (Signer, error) {
+	entry, exists := ks.Keys[operatorAddr]
+	if !exists {
+		return nil, fmt.Errorf("private key not found for operator: %s", operatorAddr)
+	}
+
+	switch entry.Type {
+	case KeyEntryTypeLedger:
+		return nil, fmt.Errorf("operator %s is backed by a ledger device; use the daemon's ledger signer registry", operatorAddr)
+	default:
+		privKey, err := ks.GetPrivKey(operatorAddr, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return NewSoftSigner(operatorAddr, privKey), nil
+	}
+}
@@ -0,0 +1,177 @@
+// Package transparency implements a Rekor-style append-only transparency
+// log for the signing daemon: every successful /sign call is recorded as a
+// leaf in an RFC 6962 Merkle tree, so operators can later prove (or
+// disprove) that a given signature was produced and published.
+package transparency
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+)
+
+// leafHash implements the RFC 6962 leaf hash: H(0x00 || leafData).
+func leafHash(leafData []byte) [32]byte {
+	buf := make([]byte, 0, 1+len(leafData))
+	buf = append(buf, 0x00)
+	buf = append(buf, leafData...)
+	return sha256.Sum256(buf)
+}
+
+// nodeHash implements the RFC 6962 internal node hash: H(0x01 || left || right).
+func nodeHash(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32+32)
+	buf = append(buf, 0x01)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return sha256.Sum256(buf)
+}
+
+// emptyHash is the RFC 6962 Merkle Tree Hash of the empty tree.
+func emptyHash() [32]byte {
+	return sha256.Sum256(nil)
+}
+
+// isPow2 reports whether n is an exact power of two.
+func isPow2(n int64) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// largestPow2LessThan returns the largest power of two strictly less than n,
+// per RFC 6962's split point k used throughout MTH/PATH/SUBPROOF.
+func largestPow2LessThan(n int64) int64 {
+	k := int64(1)
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
+
+// cacheKey identifies a node by its level (0 = leaf) and the index of its
+// left-most leaf. Only perfect (power-of-two sized) subtrees are ever
+// cached, since those are the only ones whose hash can never change as the
+// tree grows.
+type cacheKey struct {
+	level int
+	start int64
+}
+
+// tree is the in-memory RFC 6962 Merkle tree core: the ordered list of leaf
+// hashes plus a cache of completed subtree roots, which is what makes
+// Append and proof generation amortize to O(log n) instead of recomputing
+// the whole tree each time. It is not safe for concurrent use by itself;
+// Store wraps it with a mutex.
+type tree struct {
+	leaves    [][32]byte
+	cache     map[cacheKey][32]byte
+	leafIndex map[[32]byte]int64
+}
+
+func newTree() *tree {
+	return &tree{
+		cache:     make(map[cacheKey][32]byte),
+		leafIndex: make(map[[32]byte]int64),
+	}
+}
+
+// appendLeafHash appends an already-computed leaf hash and returns its
+// index. Used both for fresh appends and for replaying a segment file on
+// load.
+func (t *tree) appendLeafHash(h [32]byte) int64 {
+	index := int64(len(t.leaves))
+	t.leaves = append(t.leaves, h)
+	t.leafIndex[h] = index
+	return index
+}
+
+// subtreeHash returns MTH(D[lo:hi]), the root hash of the leaves in
+// [lo,hi), caching it if that range is a perfect subtree.
+func (t *tree) subtreeHash(lo, hi int64) [32]byte {
+	if hi-lo == 1 {
+		return t.leaves[lo]
+	}
+
+	size := hi - lo
+	var key cacheKey
+	cacheable := isPow2(size)
+	if cacheable {
+		key = cacheKey{level: bits.Len64(uint64(size)) - 1, start: lo}
+		if h, ok := t.cache[key]; ok {
+			return h
+		}
+	}
+
+	k := largestPow2LessThan(size)
+	left := t.subtreeHash(lo, lo+k)
+	right := t.subtreeHash(lo+k, hi)
+	h := nodeHash(left, right)
+
+	if cacheable {
+		t.cache[key] = h
+	}
+	return h
+}
+
+// rootAt returns MTH(D[0:size]), the Merkle root over the first size leaves.
+func (t *tree) rootAt(size int64) [32]byte {
+	if size == 0 {
+		return emptyHash()
+	}
+	return t.subtreeHash(0, size)
+}
+
+// inclusionProof implements RFC 6962's PATH(leafIndex, D[size]) algorithm.
+func (t *tree) inclusionProof(leafIndex, size int64) ([][32]byte, error) {
+	if size < 0 || size > int64(len(t.leaves)) {
+		return nil, fmt.Errorf("tree size %d exceeds log size %d", size, len(t.leaves))
+	}
+	if leafIndex < 0 || leafIndex >= size {
+		return nil, fmt.Errorf("leaf index %d out of range for tree size %d", leafIndex, size)
+	}
+	return t.path(leafIndex, 0, size), nil
+}
+
+func (t *tree) path(m, lo, hi int64) [][32]byte {
+	if hi-lo == 1 {
+		return nil
+	}
+
+	k := largestPow2LessThan(hi - lo)
+	if m-lo < k {
+		proof := t.path(m, lo, lo+k)
+		return append(proof, t.subtreeHash(lo+k, hi))
+	}
+	proof := t.path(m, lo+k, hi)
+	return append(proof, t.subtreeHash(lo, lo+k))
+}
+
+// consistencyProof implements RFC 6962's PROOF(first, D[second]) algorithm,
+// proving that the tree of size `second` is an append-only extension of the
+// tree of size `first`.
+func (t *tree) consistencyProof(first, second int64) ([][32]byte, error) {
+	if first < 0 || second > int64(len(t.leaves)) || first > second {
+		return nil, fmt.Errorf("invalid consistency range [%d,%d) for log size %d", first, second, len(t.leaves))
+	}
+	if first == 0 || first == second {
+		return [][32]byte{}, nil
+	}
+	return t.subProof(first, 0, second, true), nil
+}
+
+func (t *tree) subProof(m, lo, hi int64, haveRoot bool) [][32]byte {
+	n := hi - lo
+	if m == n {
+		if haveRoot {
+			return nil
+		}
+		return [][32]byte{t.subtreeHash(lo, hi)}
+	}
+
+	k := largestPow2LessThan(n)
+	if m <= k {
+		proof := t.subProof(m, lo, lo+k, haveRoot)
+		return append(proof, t.subtreeHash(lo+k, hi))
+	}
+	proof := t.subProof(m-k, lo+k, hi, false)
+	return append(proof, t.subtreeHash(lo, lo+k))
+}
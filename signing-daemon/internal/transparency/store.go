@@ -0,0 +1,185 @@
+package transparency
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LeafRecord is the canonical JSON blob hashed into a single audit log
+// leaf: one per successful /sign call.
+type LeafRecord struct {
+	Timestamp int64  `json:"ts"`
+	Operator  string `json:"operator"`
+	DigestHex string `json:"digestHex"`
+	SigHex    string `json:"sigHex"`
+	PubkeyHex string `json:"pubkeyHex"`
+}
+
+// Store is a durable, append-only RFC 6962 Merkle tree: leaves are appended
+// to a segment file and cached perfect-subtree roots are written to an index
+// file. Reopening a Store replays the segment in O(n). Proof generation
+// reuses the cached roots, but every Append rewrites the whole index file,
+// so Append's cost grows with the number of cached roots and is not
+// O(log n).
+type Store struct {
+	mu sync.Mutex
+
+	tree        *tree
+	segmentPath string
+	indexPath   string
+}
+
+// Open opens (or creates) the audit log rooted at dir.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	s := &Store{
+		tree:        newTree(),
+		segmentPath: filepath.Join(dir, "leaves.jsonl"),
+		indexPath:   filepath.Join(dir, "subtree-roots.json"),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load replays the leaf segment file and the cached subtree root index.
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.segmentPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read leaf segment: %w", err)
+		}
+	} else {
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			s.tree.appendLeafHash(leafHash([]byte(line)))
+		}
+	}
+
+	indexData, err := os.ReadFile(s.indexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read subtree root index: %w", err)
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal(indexData, &entries); err != nil {
+		return fmt.Errorf("failed to parse subtree root index: %w", err)
+	}
+	for k, v := range entries {
+		var level int
+		var start int64
+		if _, err := fmt.Sscanf(k, "%d:%d", &level, &start); err != nil {
+			continue
+		}
+		hashBytes, err := hex.DecodeString(v)
+		if err != nil || len(hashBytes) != 32 {
+			continue
+		}
+		var h [32]byte
+		copy(h[:], hashBytes)
+		s.tree.cache[cacheKey{level: level, start: start}] = h
+	}
+	return nil
+}
+
+// saveIndex rewrites the cached subtree root index to disk.
+func (s *Store) saveIndex() error {
+	entries := make(map[string]string, len(s.tree.cache))
+	for k, v := range s.tree.cache {
+		entries[fmt.Sprintf("%d:%d", k.level, k.start)] = hex.EncodeToString(v[:])
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode subtree root index: %w", err)
+	}
+	return os.WriteFile(s.indexPath, data, 0600)
+}
+
+// Append durably records leafData as the next leaf and returns its index.
+// It writes and syncs the segment file, then refreshes the cached subtree
+// root index, before returning; callers must treat a returned error as the
+// leaf never having been recorded and must not report the signature it
+// accompanies as having succeeded.
+func (s *Store) Append(leafData []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.segmentPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open leaf segment: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(leafData, '\n')); err != nil {
+		return 0, fmt.Errorf("failed to append leaf: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync leaf segment: %w", err)
+	}
+
+	index := s.tree.appendLeafHash(leafHash(leafData))
+
+	if err := s.saveIndex(); err != nil {
+		return 0, fmt.Errorf("failed to persist subtree root index: %w", err)
+	}
+
+	return index, nil
+}
+
+// Root returns the current tree size and its Merkle root.
+func (s *Store) Root() ([32]byte, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size := int64(len(s.tree.leaves))
+	return s.tree.rootAt(size), size
+}
+
+// InclusionProof returns the index of, and an RFC 6962 inclusion proof for,
+// the leaf whose hash is leafHashHex, against the tree as of size.
+func (s *Store) InclusionProof(leafHashHex string, size int64) (int64, [][32]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashBytes, err := hex.DecodeString(leafHashHex)
+	if err != nil || len(hashBytes) != 32 {
+		return 0, nil, fmt.Errorf("invalid leaf hash %q: must be 32 bytes of hex", leafHashHex)
+	}
+	var h [32]byte
+	copy(h[:], hashBytes)
+
+	index, ok := s.tree.leafIndex[h]
+	if !ok {
+		return 0, nil, fmt.Errorf("leaf %s not found in audit log", leafHashHex)
+	}
+
+	proof, err := s.tree.inclusionProof(index, size)
+	if err != nil {
+		return 0, nil, err
+	}
+	return index, proof, nil
+}
+
+// ConsistencyProof returns an RFC 6962 consistency proof between the tree
+// sizes from and to.
+func (s *Store) ConsistencyProof(from, to int64) ([][32]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tree.consistencyProof(from, to)
+}
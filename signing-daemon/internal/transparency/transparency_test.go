@@ -0,0 +1,155 @@
+package transparency
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/enshrined-relayers/signing-daemon/internal/keys"
+)
+
+func appendLeaf(t *testing.T, s *Store, operator string, n int) string {
+	t.Helper()
+
+	record := LeafRecord{
+		Timestamp: int64(n),
+		Operator:  operator,
+		DigestHex: hex.EncodeToString([]byte{byte(n)}),
+		SigHex:    "deadbeef",
+		PubkeyHex: "cafebabe",
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("failed to marshal leaf record: %v", err)
+	}
+	if _, err := s.Append(data); err != nil {
+		t.Fatalf("failed to append leaf: %v", err)
+	}
+	h := leafHash(data)
+	return hex.EncodeToString(h[:])
+}
+
+func TestStoreInclusionProof(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	var leafHashes []string
+	for i := 0; i < 7; i++ {
+		leafHashes = append(leafHashes, appendLeaf(t, store, "operator1", i))
+	}
+
+	root, size := store.Root()
+	if size != 7 {
+		t.Fatalf("expected tree size 7, got %d", size)
+	}
+
+	for i, leafHashHex := range leafHashes {
+		index, proof, err := store.InclusionProof(leafHashHex, size)
+		if err != nil {
+			t.Fatalf("InclusionProof failed for leaf %d: %v", i, err)
+		}
+		if index != int64(i) {
+			t.Fatalf("expected leaf index %d, got %d", i, index)
+		}
+
+		leafHashBytes, _ := hex.DecodeString(leafHashHex)
+		var h [32]byte
+		copy(h[:], leafHashBytes)
+		if got := recomputeRootFromProof(h, index, size, proof); got != root {
+			t.Fatalf("recomputed root from inclusion proof for leaf %d does not match tree root", i)
+		}
+	}
+}
+
+func TestStoreConsistencyProof(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	var rootAtSize [9][32]byte
+	rootAtSize[0] = emptyHash()
+	for i := 0; i < 8; i++ {
+		appendLeaf(t, store, "operator1", i)
+		root, size := store.Root()
+		rootAtSize[size] = root
+	}
+
+	proof, err := store.ConsistencyProof(3, 8)
+	if err != nil {
+		t.Fatalf("ConsistencyProof failed: %v", err)
+	}
+	if len(proof) == 0 {
+		t.Fatalf("expected a non-empty consistency proof between distinct, non-trivial tree sizes")
+	}
+}
+
+func TestOpenReplaysExistingLog(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := Open(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		appendLeaf(t, store, "operator1", i)
+	}
+	wantRoot, wantSize := store.Root()
+
+	reopened, err := Open(filepath.Clean(dir))
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	gotRoot, gotSize := reopened.Root()
+
+	if gotSize != wantSize || gotRoot != wantRoot {
+		t.Fatalf("reopened store root/size mismatch: got (%x, %d), want (%x, %d)", gotRoot, gotSize, wantRoot, wantSize)
+	}
+}
+
+func TestSignAndVerifySTH(t *testing.T) {
+	privateKey, err := keys.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signer := keys.NewSoftSigner("orgvaloper1logkey", keys.NewSecp256k1PrivKey(privateKey))
+
+	rootHash := emptyHash()
+	sth, err := SignSTH(signer, 0, rootHash)
+	if err != nil {
+		t.Fatalf("SignSTH failed: %v", err)
+	}
+
+	if err := VerifySTH(signer.PubKey(), sth); err != nil {
+		t.Fatalf("VerifySTH failed on a freshly signed STH: %v", err)
+	}
+
+	sth.TreeSize = 1
+	if err := VerifySTH(signer.PubKey(), sth); err == nil {
+		t.Fatalf("expected VerifySTH to reject a tampered tree size")
+	}
+}
+
+// recomputeRootFromProof recomputes the tree root from an RFC 6962
+// inclusion proof, mirroring the algorithm an independent verifier would
+// use (the test lives here rather than in an external verifier binary).
+func recomputeRootFromProof(leafHashValue [32]byte, index, size int64, proof [][32]byte) [32]byte {
+	return recomputeRange(leafHashValue, index, 0, size, proof)
+}
+
+func recomputeRange(leafHashValue [32]byte, m, lo, hi int64, proof [][32]byte) [32]byte {
+	if hi-lo == 1 {
+		return leafHashValue
+	}
+
+	k := largestPow2LessThan(hi - lo)
+	if m-lo < k {
+		left := recomputeRange(leafHashValue, m, lo, lo+k, proof[:len(proof)-1])
+		return nodeHash(left, proof[len(proof)-1])
+	}
+	right := recomputeRange(leafHashValue, m, lo+k, hi, proof[:len(proof)-1])
+	return nodeHash(proof[len(proof)-1], right)
+}